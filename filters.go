@@ -0,0 +1,169 @@
+// Pluggable redaction/filter pipeline: runs over the message and fields of
+// every event logged through a Logger (Trace..Error, their Ctx variants, and
+// Fatal) before it is emitted, so secrets (passwords, tokens, authorization
+// headers, PII) can be scrubbed centrally instead of relying on every call
+// site to remember to strip them. Events written through SlogHandler do not
+// pass through the filters.
+
+package prezerolog
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+// Filter inspects (and may rewrite or drop) a log event before it is
+// written. Returning ok=false drops the event entirely.
+type Filter interface {
+	Apply(level zerolog.Level, msg string, fields map[string]interface{}) (string, map[string]interface{}, bool)
+}
+
+// FilterFunc adapts a plain function to the Filter interface.
+type FilterFunc func(level zerolog.Level, msg string, fields map[string]interface{}) (string, map[string]interface{}, bool)
+
+func (f FilterFunc) Apply(level zerolog.Level, msg string, fields map[string]interface{}) (string, map[string]interface{}, bool) {
+	return f(level, msg, fields)
+}
+
+var (
+	filtersMu sync.Mutex
+	filters   []Filter
+)
+
+// AddFilter registers f to run on every subsequent Logger event, in the
+// order registered; SlogHandler events are not filtered. Safe to call before
+// or after InitLogging.
+func AddFilter(f Filter) {
+	filtersMu.Lock()
+	defer filtersMu.Unlock()
+	filters = append(filters, f)
+}
+
+// hasFilters reports whether any filter is registered, so the hot logging
+// path can skip building a fields map when there is nothing to run it through.
+func hasFilters() bool {
+	filtersMu.Lock()
+	defer filtersMu.Unlock()
+	return len(filters) > 0
+}
+
+// runFilters applies all registered filters in order, short-circuiting if
+// any filter drops the event.
+func runFilters(level zerolog.Level, msg string, fields map[string]interface{}) (string, map[string]interface{}, bool) {
+	filtersMu.Lock()
+	chain := make([]Filter, len(filters))
+	copy(chain, filters)
+	filtersMu.Unlock()
+
+	for _, f := range chain {
+		var ok bool
+		msg, fields, ok = f.Apply(level, msg, fields)
+		if !ok {
+			return msg, fields, false
+		}
+	}
+	return msg, fields, true
+}
+
+const redactedPlaceholder = "***"
+
+// KeyRedactor returns a Filter that replaces the value of any field whose
+// key matches one of keys (case-insensitive) with "***".
+func KeyRedactor(keys ...string) Filter {
+	set := make(map[string]struct{}, len(keys))
+	for _, k := range keys {
+		set[strings.ToLower(strings.TrimSpace(k))] = struct{}{}
+	}
+	return FilterFunc(func(_ zerolog.Level, msg string, fields map[string]interface{}) (string, map[string]interface{}, bool) {
+		for k := range fields {
+			if _, hit := set[strings.ToLower(k)]; hit {
+				fields[k] = redactedPlaceholder
+			}
+		}
+		return msg, fields, true
+	})
+}
+
+// ValueRegexRedactor returns a Filter that scans string values (including
+// nested maps and structs, flattened by mergeStruct) and rewrites any
+// substring matching re with "***".
+func ValueRegexRedactor(re *regexp.Regexp) Filter {
+	return FilterFunc(func(_ zerolog.Level, msg string, fields map[string]interface{}) (string, map[string]interface{}, bool) {
+		msg = redactMatches(re, msg)
+		for k, v := range fields {
+			fields[k] = redactValue(re, v)
+		}
+		return msg, fields, true
+	})
+}
+
+func redactMatches(re *regexp.Regexp, s string) string {
+	return re.ReplaceAllString(s, redactedPlaceholder)
+}
+
+func redactValue(re *regexp.Regexp, v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return redactMatches(re, val)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, nested := range val {
+			out[k] = redactValue(re, nested)
+		}
+		return out
+	default:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+			rv = rv.Elem()
+		}
+		if rv.Kind() != reflect.Struct {
+			return v
+		}
+		flat := make(map[string]interface{})
+		mergeStruct(flat, rv.Interface())
+		return redactValue(re, flat)
+	}
+}
+
+// LevelFilter returns a Filter that drops any event below min.
+func LevelFilter(min zerolog.Level) Filter {
+	return FilterFunc(func(level zerolog.Level, msg string, fields map[string]interface{}) (string, map[string]interface{}, bool) {
+		return msg, fields, level >= min
+	})
+}
+
+// SamplerFilter wraps a zerolog.Sampler (e.g. &zerolog.BasicSampler{N: 10})
+// and applies it per distinct field key, so chatty keys can be sampled
+// independently of the global LOG_SAMPLING_N.
+func SamplerFilter(key string, sampler zerolog.Sampler) Filter {
+	return FilterFunc(func(level zerolog.Level, msg string, fields map[string]interface{}) (string, map[string]interface{}, bool) {
+		if _, has := fields[key]; !has {
+			return msg, fields, true
+		}
+		return msg, fields, sampler.Sample(level)
+	})
+}
+
+// defaultKeyRedactorFromEnv builds a KeyRedactor from LOG_REDACT_KEYS (a
+// comma-separated list, e.g. "password,token,authorization"), or returns
+// nil if the env var is unset.
+func defaultKeyRedactorFromEnv() Filter {
+	raw := getEnv("LOG_REDACT_KEYS", "")
+	if raw == "" {
+		return nil
+	}
+	var keys []string
+	for _, k := range strings.Split(raw, ",") {
+		if k = strings.TrimSpace(k); k != "" {
+			keys = append(keys, k)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return KeyRedactor(keys...)
+}
@@ -0,0 +1,177 @@
+// Pluggable output formats: transforms the canonical zerolog JSON line into
+// formats third-party ingestion pipelines expect (logfmt, GELF), on top of
+// the existing minimal/full/extended/json console renderings. New formats
+// can be registered at runtime via RegisterFormat and used by both
+// LOG_CONSOLE_OUTPUT and LOG_FILE_OUTPUT.
+
+package prezerolog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FormatterFunc transforms one canonical zerolog JSON line into another
+// target format. The returned bytes are written verbatim, so implementations
+// own their own line termination.
+type FormatterFunc func([]byte) []byte
+
+var (
+	formatsMu sync.Mutex
+	formats   = map[string]FormatterFunc{
+		"logfmt": formatLogfmt,
+		"gelf":   formatGELF,
+	}
+)
+
+// RegisterFormat adds or overrides a named output format usable by
+// LOG_CONSOLE_OUTPUT / LOG_FILE_OUTPUT.
+func RegisterFormat(name string, fn FormatterFunc) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[strings.ToLower(strings.TrimSpace(name))] = fn
+}
+
+func lookupFormat(name string) (FormatterFunc, bool) {
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	fn, ok := formats[strings.ToLower(strings.TrimSpace(name))]
+	return fn, ok
+}
+
+// formatWriter applies a FormatterFunc to each canonical JSON line before
+// forwarding it to the underlying writer.
+type formatWriter struct {
+	out io.Writer
+	fn  FormatterFunc
+}
+
+func (f *formatWriter) Write(p []byte) (int, error) {
+	if _, err := f.out.Write(f.fn(p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// ---------- logfmt ----------
+
+func formatLogfmt(p []byte) []byte {
+	var line map[string]interface{}
+	if err := json.Unmarshal(p, &line); err != nil {
+		return p
+	}
+
+	var b strings.Builder
+	written := make(map[string]bool, len(line))
+
+	writeKV := func(k string, v interface{}) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(logfmtValue(v))
+	}
+
+	for _, k := range []string{"time", "level", "caller", "msg"} {
+		if v, ok := line[k]; ok {
+			writeKV(k, v)
+			written[k] = true
+		}
+	}
+
+	rest := make([]string, 0, len(line))
+	for k := range line {
+		if !written[k] {
+			rest = append(rest, k)
+		}
+	}
+	sort.Strings(rest)
+	for _, k := range rest {
+		writeKV(k, line[k])
+	}
+
+	b.WriteByte('\n')
+	return []byte(b.String())
+}
+
+func logfmtValue(v interface{}) string {
+	s := fmt.Sprintf("%v", v)
+	if s == "" || strings.ContainsAny(s, " =\"\n\r\t") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// ---------- GELF (Graylog Extended Log Format, v1.1) ----------
+
+func formatGELF(p []byte) []byte {
+	var line map[string]interface{}
+	if err := json.Unmarshal(p, &line); err != nil {
+		return p
+	}
+
+	host, _ := os.Hostname()
+	msg, _ := line["msg"].(string)
+
+	out := map[string]interface{}{
+		"version":       "1.1",
+		"host":          host,
+		"short_message": msg,
+		"timestamp":     gelfTimestamp(line["time"]),
+		"level":         gelfSyslogLevel(line["level"]),
+	}
+
+	for k, v := range line {
+		switch k {
+		case "time", "level", "msg":
+			continue
+		default:
+			out["_"+k] = v
+		}
+	}
+
+	b, err := json.Marshal(out)
+	if err != nil {
+		return p
+	}
+	return append(b, '\n')
+}
+
+func gelfTimestamp(v interface{}) float64 {
+	s, _ := v.(string)
+	if s != "" {
+		if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return float64(t.UnixNano()) / 1e9
+		}
+	}
+	return float64(time.Now().UTC().UnixNano()) / 1e9
+}
+
+// gelfSyslogLevel maps our level strings to syslog numeric severity, as
+// required by the GELF spec.
+func gelfSyslogLevel(v interface{}) int {
+	switch s, _ := v.(string); s {
+	case "trace", "debug":
+		return 7
+	case "info":
+		return 6
+	case "warn":
+		return 4
+	case "error":
+		return 3
+	case "fatal":
+		return 2
+	case "panic":
+		return 0
+	default:
+		return 6
+	}
+}
@@ -0,0 +1,198 @@
+// Dynamic log-level control: lets operators raise or lower console/file
+// verbosity at runtime, without restarting the process, via SIGUSR1 or an
+// HTTP endpoint suitable for mounting alongside pprof.
+
+package prezerolog
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	levelMu            sync.Mutex
+	consoleLevelAtomic *atomic.Int32
+	fileLevelAtomic    *atomic.Int32
+)
+
+func setConsoleLevelAtomic(a *atomic.Int32) {
+	levelMu.Lock()
+	consoleLevelAtomic = a
+	levelMu.Unlock()
+}
+
+func setFileLevelAtomic(a *atomic.Int32) {
+	levelMu.Lock()
+	fileLevelAtomic = a
+	levelMu.Unlock()
+}
+
+// SetConsoleLevel swaps the console destination's minimum level at runtime.
+// It is a no-op if console output is disabled.
+func SetConsoleLevel(level zerolog.Level) {
+	levelMu.Lock()
+	a := consoleLevelAtomic
+	levelMu.Unlock()
+	if a != nil {
+		a.Store(int32(level))
+	}
+}
+
+// SetFileLevel swaps the file destination's minimum level at runtime. It is
+// a no-op if file output is disabled.
+func SetFileLevel(level zerolog.Level) {
+	levelMu.Lock()
+	a := fileLevelAtomic
+	levelMu.Unlock()
+	if a != nil {
+		a.Store(int32(level))
+	}
+}
+
+// ConsoleLevel returns the console destination's current minimum level, or
+// zerolog.NoLevel if console output is disabled.
+func ConsoleLevel() zerolog.Level {
+	levelMu.Lock()
+	a := consoleLevelAtomic
+	levelMu.Unlock()
+	if a == nil {
+		return zerolog.NoLevel
+	}
+	return zerolog.Level(a.Load())
+}
+
+// FileLevel returns the file destination's current minimum level, or
+// zerolog.NoLevel if file output is disabled.
+func FileLevel() zerolog.Level {
+	levelMu.Lock()
+	a := fileLevelAtomic
+	levelMu.Unlock()
+	if a == nil {
+		return zerolog.NoLevel
+	}
+	return zerolog.Level(a.Load())
+}
+
+// installSignalLevelHandler wires a SIGUSR1 handler when LOG_SIGNAL_LEVEL is
+// true, stepping the console level trace->debug->info->warn->info on each
+// signal. Once at info or warn, further signals alternate between warn and
+// info; trace and debug are not re-entered by the cycle.
+func installSignalLevelHandler() {
+	if !getEnvBool("LOG_SIGNAL_LEVEL", false) {
+		return
+	}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+	go func() {
+		for range sigCh {
+			next := nextSignalLevel(ConsoleLevel())
+			SetConsoleLevel(next)
+			log.Logger.Info().Str("console_level", next.String()).Msg("log level changed via SIGUSR1")
+		}
+	}()
+}
+
+func nextSignalLevel(current zerolog.Level) zerolog.Level {
+	switch current {
+	case zerolog.TraceLevel:
+		return zerolog.DebugLevel
+	case zerolog.DebugLevel:
+		return zerolog.InfoLevel
+	case zerolog.InfoLevel:
+		return zerolog.WarnLevel
+	case zerolog.WarnLevel:
+		return zerolog.InfoLevel
+	default:
+		return zerolog.InfoLevel
+	}
+}
+
+// levelPayload is the JSON body accepted/returned by LevelHandler.
+type levelPayload struct {
+	Console string `json:"console,omitempty"`
+	File    string `json:"file,omitempty"`
+}
+
+// LevelHandler returns an http.Handler suitable for mounting at e.g.
+// /debug/log alongside pprof. GET returns the current console/file levels
+// as JSON; PUT {"console":"debug","file":"info"} updates them. A `?ttl=5m`
+// query parameter reverts the change after the given duration, preventing
+// forgotten debug-in-prod situations.
+func LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeLevelJSON(w)
+		case http.MethodPut:
+			handleLevelPut(w, r)
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func writeLevelJSON(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(levelPayload{
+		Console: levelString(ConsoleLevel()),
+		File:    levelString(FileLevel()),
+	})
+}
+
+func levelString(l zerolog.Level) string {
+	if l == zerolog.NoLevel {
+		return ""
+	}
+	return l.String()
+}
+
+func handleLevelPut(w http.ResponseWriter, r *http.Request) {
+	var payload levelPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		http.Error(w, "invalid body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ttl, hasTTL := parseTTL(r.URL.Query().Get("ttl"))
+	prevConsole, prevFile := ConsoleLevel(), FileLevel()
+
+	if payload.Console != "" {
+		if lvl := parseLevel(payload.Console); lvl != zerolog.NoLevel {
+			SetConsoleLevel(lvl)
+		}
+	}
+	if payload.File != "" {
+		if lvl := parseLevel(payload.File); lvl != zerolog.NoLevel {
+			SetFileLevel(lvl)
+		}
+	}
+
+	if hasTTL {
+		time.AfterFunc(ttl, func() {
+			SetConsoleLevel(prevConsole)
+			SetFileLevel(prevFile)
+		})
+	}
+
+	writeLevelJSON(w)
+}
+
+func parseTTL(raw string) (time.Duration, bool) {
+	if raw == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d <= 0 {
+		return 0, false
+	}
+	return d, true
+}
@@ -0,0 +1,125 @@
+// Per-subsystem named loggers with hierarchical level overrides. Large
+// applications can silence a chatty subsystem in production without
+// flipping the global console/file level, a pattern common among
+// slog/logrus/zap users migrating to this module. An override only
+// narrows: it can raise a component's effective floor above the global
+// console/file level, but since that level is still the final gate at the
+// destination, making one component *more* verbose than the rest requires
+// the global LOG_CONSOLE_LEVEL/LOG_FILE_LEVEL to already be permissive
+// enough to carry it.
+
+package prezerolog
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+var (
+	levelOverridesMu sync.RWMutex
+	levelOverrides   map[string]zerolog.Level
+	levelGeneration  atomic.Int32
+)
+
+// Named returns a child logger that attaches a "component" field to every
+// event and, if LOG_LEVELS or SetLevel has an entry for name (or one of its
+// dotted prefixes), filters events below that level before they reach the
+// configured destinations.
+func Named(name string) *Logger {
+	base := ensure()
+	child := &Logger{
+		rotator:    base.rotator,
+		consoleOut: base.consoleOut,
+		component:  name,
+	}
+	child.cachedGen.Store(-1) // force resolution on first log call
+	return child
+}
+
+// SetLevel overrides the level for name (and, unless shadowed by a more
+// specific entry, any dotted name that has it as a prefix, e.g.
+// "cache.redis" inherits from "cache"). Takes effect on the next log call
+// from every Named logger matching name, without needing to recreate them.
+func SetLevel(name string, lvl zerolog.Level) {
+	levelOverridesMu.Lock()
+	if levelOverrides == nil {
+		levelOverrides = make(map[string]zerolog.Level)
+	}
+	levelOverrides[name] = lvl
+	levelOverridesMu.Unlock()
+	levelGeneration.Add(1)
+}
+
+// parseLevelOverridesFromEnv populates the override table from LOG_LEVELS,
+// e.g. "db=debug,http=warn,cache.redis=trace".
+func parseLevelOverridesFromEnv() {
+	raw := getEnv("LOG_LEVELS", "")
+	if raw == "" {
+		return
+	}
+
+	overrides := make(map[string]zerolog.Level)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, lvlStr, found := strings.Cut(pair, "=")
+		if !found {
+			continue
+		}
+		name = strings.TrimSpace(name)
+		lvl := parseLevel(strings.TrimSpace(lvlStr))
+		if name == "" || lvl == zerolog.NoLevel {
+			continue
+		}
+		overrides[name] = lvl
+	}
+	if len(overrides) == 0 {
+		return
+	}
+
+	levelOverridesMu.Lock()
+	if levelOverrides == nil {
+		levelOverrides = make(map[string]zerolog.Level, len(overrides))
+	}
+	for k, v := range overrides {
+		levelOverrides[k] = v
+	}
+	levelOverridesMu.Unlock()
+	levelGeneration.Add(1)
+}
+
+// resolveLevelOverride walks name's dotted prefixes from most to least
+// specific (e.g. "cache.redis" then "cache") and returns the first
+// registered override, or zerolog.NoLevel if none matches.
+func resolveLevelOverride(name string) zerolog.Level {
+	levelOverridesMu.RLock()
+	defer levelOverridesMu.RUnlock()
+
+	parts := strings.Split(name, ".")
+	for i := len(parts); i > 0; i-- {
+		if lvl, ok := levelOverrides[strings.Join(parts[:i], ".")]; ok {
+			return lvl
+		}
+	}
+	return zerolog.NoLevel
+}
+
+// effectiveLevel returns l's resolved override, re-resolving from the
+// override table if the generation counter has moved since it was cached.
+// Returns zerolog.NoLevel for the root logger or when nothing overrides it.
+func (l *Logger) effectiveLevel() zerolog.Level {
+	if l.component == "" {
+		return zerolog.NoLevel
+	}
+	gen := levelGeneration.Load()
+	if l.cachedGen.Load() != gen {
+		l.cachedLevel.Store(int32(resolveLevelOverride(l.component)))
+		l.cachedGen.Store(gen)
+	}
+	return zerolog.Level(l.cachedLevel.Load())
+}
@@ -0,0 +1,242 @@
+// OpenTelemetry log destination: ships prezerolog events to an OTLP
+// collector alongside traces/spans, without a sidecar or a second log
+// format. The destination is a zerolog.LevelWriter like any other, so it
+// composes with the existing multiLevelWriter fan-out.
+
+package prezerolog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	otlpMu    sync.Mutex
+	otlpDests []levelDest
+	otlpWrtrs []*otlpWriter
+)
+
+// WithOTLPExporter builds an OTLP log destination talking to endpoint and
+// queues it to be wired into the multiLevelWriter by the next InitLogging
+// call. Unlike AddFilter, the destination list is only drained once, inside
+// configureZerolog - call WithOTLPExporter before InitLogging, not after.
+// LOG_OTLP_* env vars do the same thing automatically when set.
+func WithOTLPExporter(endpoint string, opts ...otlploggrpc.Option) error {
+	w, err := newOTLPWriter(endpoint, opts...)
+	if err != nil {
+		return err
+	}
+
+	level := parseLevel(getEnv("LOG_OTLP_LEVEL", "info"))
+	if level == zerolog.NoLevel {
+		level = zerolog.InfoLevel
+	}
+
+	otlpMu.Lock()
+	otlpDests = append(otlpDests, levelDest{w: w, min: newAtomicLevel(level)})
+	otlpWrtrs = append(otlpWrtrs, w)
+	otlpMu.Unlock()
+
+	if AppLogger != nil {
+		fmt.Fprintf(os.Stderr, "prezerolog: WithOTLPExporter called after InitLogging; this destination will sit unused until InitLogging runs again\n")
+	}
+	return nil
+}
+
+// otlpExporterFromEnv registers a destination from LOG_OTLP_ENDPOINT if
+// set, mirroring defaultKeyRedactorFromEnv's env-driven auto-config.
+func otlpExporterFromEnv() {
+	endpoint := getEnv("LOG_OTLP_ENDPOINT", "")
+	if endpoint == "" {
+		return
+	}
+	var opts []otlploggrpc.Option
+	if getEnvBool("LOG_OTLP_INSECURE", false) {
+		opts = append(opts, otlploggrpc.WithInsecure())
+	}
+	if err := WithOTLPExporter(endpoint, opts...); err != nil {
+		fmt.Fprintf(os.Stderr, "prezerolog: failed to start OTLP exporter: %v\n", err)
+	}
+}
+
+// pendingOTLPDests drains the destinations registered via WithOTLPExporter
+// / LOG_OTLP_ENDPOINT so configureZerolog can fold them into the same
+// multiLevelWriter as file/console.
+func pendingOTLPDests() []levelDest {
+	otlpMu.Lock()
+	defer otlpMu.Unlock()
+	dests := otlpDests
+	otlpDests = nil
+	return dests
+}
+
+// shutdownOTLP flushes and closes every registered OTLP destination. Called
+// from Logger.Shutdown().
+func shutdownOTLP() {
+	otlpMu.Lock()
+	writers := otlpWrtrs
+	otlpWrtrs = nil
+	otlpMu.Unlock()
+
+	for _, w := range writers {
+		w.Close()
+	}
+}
+
+// otlpWriter adapts an OTLP log exporter to zerolog.LevelWriter by parsing
+// the already-built canonical JSON line once and mapping trace_id/span_id
+// onto OTel's TraceID/SpanID so traces correlate automatically.
+type otlpWriter struct {
+	exporter sdklog.Exporter
+	batch    chan sdklog.Record
+	done     chan struct{}
+	warnOnce sync.Once
+}
+
+func newOTLPWriter(endpoint string, opts ...otlploggrpc.Option) (*otlpWriter, error) {
+	allOpts := append([]otlploggrpc.Option{otlploggrpc.WithEndpoint(endpoint)}, opts...)
+	exp, err := otlploggrpc.New(context.Background(), allOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("prezerolog: failed to create OTLP log exporter: %w", err)
+	}
+
+	w := &otlpWriter{
+		exporter: exp,
+		batch:    make(chan sdklog.Record, 256),
+		done:     make(chan struct{}),
+	}
+	go w.run()
+	return w, nil
+}
+
+func (w *otlpWriter) run() {
+	ticker := time.NewTicker(2 * time.Second)
+	defer ticker.Stop()
+
+	var pending []sdklog.Record
+	flush := func() {
+		if len(pending) == 0 {
+			return
+		}
+		if err := w.exporter.Export(context.Background(), pending); err != nil {
+			w.warnOnce.Do(func() {
+				fmt.Fprintf(os.Stderr, "prezerolog: OTLP collector unreachable, dropping log records: %v\n", err)
+			})
+		}
+		pending = pending[:0]
+	}
+
+	for {
+		select {
+		case rec, ok := <-w.batch:
+			if !ok {
+				flush()
+				return
+			}
+			pending = append(pending, rec)
+			if len(pending) >= 64 {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-w.done:
+			flush()
+			return
+		}
+	}
+}
+
+// Write implements io.Writer. multiLevelWriter fans events out through
+// Write, so the level arrives as zerolog.NoLevel and WriteLevel reads it back
+// from the line's "level" field.
+func (w *otlpWriter) Write(p []byte) (int, error) {
+	return w.WriteLevel(zerolog.NoLevel, p)
+}
+
+func (w *otlpWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	var line map[string]interface{}
+	if err := json.Unmarshal(p, &line); err != nil {
+		return len(p), nil // best-effort destination, never breaks other writers
+	}
+	if level == zerolog.NoLevel {
+		if s, _ := line["level"].(string); s != "" {
+			if parsed, err := zerolog.ParseLevel(s); err == nil {
+				level = parsed
+			}
+		}
+	}
+
+	rec := sdklog.Record{}
+	rec.SetTimestamp(time.Now().UTC())
+	rec.SetSeverity(otelSeverity(level))
+	rec.SetSeverityText(level.String())
+	if msg, _ := line["msg"].(string); msg != "" {
+		rec.SetBody(otellog.StringValue(msg))
+	}
+
+	for k, v := range line {
+		switch k {
+		case "time", "level", "msg":
+			continue
+		case "trace_id":
+			if s, ok := v.(string); ok {
+				if id, err := trace.TraceIDFromHex(s); err == nil {
+					rec.SetTraceID(id)
+				} else {
+					rec.AddAttributes(otellog.String("trace_id", s))
+				}
+			}
+		case "span_id":
+			if s, ok := v.(string); ok {
+				if id, err := trace.SpanIDFromHex(s); err == nil {
+					rec.SetSpanID(id)
+				} else {
+					rec.AddAttributes(otellog.String("span_id", s))
+				}
+			}
+		default:
+			rec.AddAttributes(otellog.String(k, fmt.Sprintf("%v", v)))
+		}
+	}
+
+	select {
+	case w.batch <- rec:
+	default:
+		// batch full: drop rather than block the hot logging path
+	}
+	return len(p), nil
+}
+
+func (w *otlpWriter) Close() {
+	close(w.done)
+	_ = w.exporter.Shutdown(context.Background())
+}
+
+func otelSeverity(level zerolog.Level) otellog.Severity {
+	switch level {
+	case zerolog.TraceLevel:
+		return otellog.SeverityTrace
+	case zerolog.DebugLevel:
+		return otellog.SeverityDebug
+	case zerolog.InfoLevel:
+		return otellog.SeverityInfo
+	case zerolog.WarnLevel:
+		return otellog.SeverityWarn
+	case zerolog.ErrorLevel:
+		return otellog.SeverityError
+	case zerolog.FatalLevel, zerolog.PanicLevel:
+		return otellog.SeverityFatal
+	default:
+		return otellog.SeverityUndefined
+	}
+}
@@ -0,0 +1,189 @@
+// Pooled event emission: the hot logging path (logEvent/logEventCtx) avoids
+// per-call map allocations and reflection by writing key/value pairs into a
+// pooled scratch buffer and dispatching to zerolog's typed Event writers
+// instead of the reflection-based Interface. Struct arguments still go
+// through reflection, but their field layout is cached by reflect.Type so
+// repeated struct types skip NumField/Field walks after the first call.
+
+package prezerolog
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type fieldEntry struct {
+	key string
+	val interface{}
+}
+
+// fieldBuf is a reusable scratch buffer of key/value pairs, pooled to avoid
+// allocating a map[string]interface{} on every log call.
+type fieldBuf struct {
+	entries []fieldEntry
+}
+
+func (b *fieldBuf) reset() {
+	b.entries = b.entries[:0]
+}
+
+// set appends key/val, or overwrites the existing entry for key if one is
+// already present, so a later argument's field wins over an earlier one's -
+// the same last-value-wins semantics map[string]interface{} gave for free
+// before this buffer replaced it. Field counts are small (a handful of
+// scalars per call), so the linear scan is cheaper than a map here.
+func (b *fieldBuf) set(key string, val interface{}) {
+	for i := range b.entries {
+		if b.entries[i].key == key {
+			b.entries[i].val = val
+			return
+		}
+	}
+	b.entries = append(b.entries, fieldEntry{key, val})
+}
+
+func (b *fieldBuf) toMap() map[string]interface{} {
+	m := make(map[string]interface{}, len(b.entries))
+	for _, e := range b.entries {
+		m[e.key] = e.val
+	}
+	return m
+}
+
+var fieldBufPool = sync.Pool{
+	New: func() interface{} { return &fieldBuf{entries: make([]fieldEntry, 0, 8)} },
+}
+
+func getFieldBuf() *fieldBuf {
+	return fieldBufPool.Get().(*fieldBuf)
+}
+
+func putFieldBuf(b *fieldBuf) {
+	b.reset()
+	fieldBufPool.Put(b)
+}
+
+// structField describes one exported field of a struct type, resolved once
+// per reflect.Type and cached in structCache.
+type structField struct {
+	name  string
+	index int
+}
+
+var structCache sync.Map // reflect.Type -> []structField
+
+func structDescriptor(rt reflect.Type) []structField {
+	if v, ok := structCache.Load(rt); ok {
+		return v.([]structField)
+	}
+	fields := make([]structField, 0, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		if f.PkgPath != "" { // unexported
+			continue
+		}
+		fields = append(fields, structField{name: f.Name, index: i})
+	}
+	// Concurrent callers may race to populate the same entry; LoadOrStore
+	// keeps whichever slice wins without requiring a lock.
+	actual, _ := structCache.LoadOrStore(rt, fields)
+	return actual.([]structField)
+}
+
+func mergeStructInto(buf *fieldBuf, obj interface{}) {
+	if obj == nil {
+		return
+	}
+	rv := reflect.ValueOf(obj)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		buf.set("value", obj)
+		return
+	}
+	for _, f := range structDescriptor(rv.Type()) {
+		buf.set(f.name, rv.Field(f.index).Interface())
+	}
+}
+
+// processLogArgsInto is the allocation-free counterpart to processLogArgs:
+// it appends fields onto buf instead of building a map.
+func processLogArgsInto(args []interface{}, buf *fieldBuf) (string, error) {
+	var message string
+	var errVal error
+	for _, arg := range args {
+		switch v := arg.(type) {
+		case string:
+			message = v
+		case error:
+			errVal = v
+		case map[string]interface{}:
+			for k, val := range v {
+				buf.set(k, val)
+			}
+		case fmt.Stringer:
+			if message == "" {
+				message = v.String()
+			} else {
+				buf.set("value", v.String())
+			}
+		default:
+			mergeStructInto(buf, v)
+		}
+	}
+	return message, errVal
+}
+
+// writeFieldsTyped writes buf's entries onto ev using zerolog's typed
+// writers (Str, Int, Bool, ...), type-switching on the value instead of
+// paying reflection.Interface's cost for the common scalar types.
+func writeFieldsTyped(ev *zerolog.Event, entries []fieldEntry) {
+	for _, e := range entries {
+		switch val := e.val.(type) {
+		case string:
+			ev.Str(e.key, val)
+		case int:
+			ev.Int(e.key, val)
+		case int32:
+			ev.Int32(e.key, val)
+		case int64:
+			ev.Int64(e.key, val)
+		case uint:
+			ev.Uint(e.key, val)
+		case uint32:
+			ev.Uint32(e.key, val)
+		case uint64:
+			ev.Uint64(e.key, val)
+		case float32:
+			ev.Float32(e.key, val)
+		case float64:
+			ev.Float64(e.key, val)
+		case bool:
+			ev.Bool(e.key, val)
+		case time.Time:
+			ev.Time(e.key, val)
+		case time.Duration:
+			ev.Dur(e.key, val)
+		case error:
+			ev.AnErr(e.key, val)
+		default:
+			ev.Interface(e.key, val)
+		}
+	}
+}
+
+func emitEvent(ev *zerolog.Event, message string) {
+	if message != "" {
+		ev.Msg(message)
+	} else {
+		ev.Send()
+	}
+}
@@ -0,0 +1,72 @@
+package prezerolog
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/rs/zerolog"
+	zlog "github.com/rs/zerolog/log"
+)
+
+func benchLogger(b *testing.B) *Logger {
+	b.Helper()
+	zlog.Logger = zerolog.New(io.Discard)
+	return &Logger{consoleOut: false}
+}
+
+func BenchmarkLogEvent_MessageOnly(b *testing.B) {
+	l := benchLogger(b)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("message only")
+	}
+}
+
+// BenchmarkLogEvent_MessageOnly_NoCaller measures the same call with caller
+// resolution turned off. userCaller's stack walk dominates the cost of a
+// message-only call, so this is the benchmark to check against a
+// zero-allocation target - the default (LOG_CALLER=true) path still pays
+// for caller info out of the box.
+func BenchmarkLogEvent_MessageOnly_NoCaller(b *testing.B) {
+	l := benchLogger(b)
+	callerEnabled.Store(false)
+	defer callerEnabled.Store(true)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("message only")
+	}
+}
+
+func BenchmarkLogEvent_MessageWithMap(b *testing.B) {
+	l := benchLogger(b)
+	fields := map[string]any{"user": "alice", "count": 3, "active": true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Info("message with fields", fields)
+	}
+}
+
+func BenchmarkLogEvent_MessageErrorAndMap(b *testing.B) {
+	l := benchLogger(b)
+	err := errors.New("boom")
+	fields := map[string]any{"user": "alice", "count": 3, "active": true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.Error("message with error and fields", err, fields)
+	}
+}
+
+func BenchmarkLogEventCtx_AllIDs(b *testing.B) {
+	l := benchLogger(b)
+	ctx := context.Background()
+	ctx = context.WithValue(ctx, CtxRequestID, "req-1")
+	ctx = context.WithValue(ctx, CtxTraceID, "trace-1")
+	ctx = context.WithValue(ctx, CtxSpanID, "span-1")
+	fields := map[string]any{"user": "alice", "count": 3, "active": true}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		l.InfoCtx(ctx, "message with ctx", fields)
+	}
+}
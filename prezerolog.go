@@ -9,6 +9,8 @@
 // - Handles string (message), error, map[string]any, and struct args
 // - Fatal logs with stack in dev and app-defined exit code (default 1)
 // - Optional sampling via LOG_SAMPLING_N (1 = no sampling)
+// - log/slog bridge via NewSlogHandler/SlogLogger (see slog.go)
+// - Named subsystem loggers with hierarchical level overrides (see named.go)
 //
 // License: MIT - 2025 Philip Andersen
 //
@@ -20,10 +22,18 @@
 // LOG_CONSOLE=true|false                        (default: true)
 // LOG_CONSOLE_LEVEL=trace|debug|info|warn       (default: info)
 // LOG_FILE_LEVEL=trace|debug|info|warn          (default: info)
-// LOG_CONSOLE_OUTPUT=minimal|full|extended|json (default: full)
+// LOG_CONSOLE_OUTPUT=minimal|full|extended|json|logfmt|gelf (default: full)
+// LOG_FILE_OUTPUT=json|logfmt|gelf              (default: json)
 // LOG_SAMPLING_N=1                              (default: 1)
 // LOG_ROTATE_MAX_SIZE=100                       (default: 100)
 // LOG_ROTATE_MAX_BACKUPS=7                      (default: 7)
+// LOG_REDACT_KEYS=password,token,authorization  (default: unset)
+// LOG_OTLP_ENDPOINT=<host:port>                 (default: unset, disabled)
+// LOG_OTLP_INSECURE=true|false                  (default: false)
+// LOG_OTLP_LEVEL=trace|debug|info|warn          (default: info)
+// LOG_SIGNAL_LEVEL=true|false                   (default: false)
+// LOG_LEVELS=db=debug,http=warn,cache.redis=trace (default: unset)
+// LOG_CALLER=true|false                         (default: true)
 
 package prezerolog
 
@@ -39,6 +49,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -52,6 +63,13 @@ type Logger struct {
 	rotator    *RotatingLogger
 	consoleOut bool
 	mu         sync.Mutex
+
+	// component and the two fields below back Named(): a child logger
+	// attaches "component" to every event and consults a cached,
+	// generation-checked level override. See named.go.
+	component   string
+	cachedLevel atomic.Int32
+	cachedGen   atomic.Int32
 }
 
 type RotatingLogger struct {
@@ -75,6 +93,19 @@ const (
 
 var AppLogger *Logger
 
+// callerEnabled gates userCaller(), which dominates the cost of a log call
+// (a stack walk plus a frame-by-frame name check). Set from LOG_CALLER in
+// InitLogging; defaults to true so caller info keeps working out of the
+// box, but callers chasing minimal per-call allocations can turn it off.
+// init seeds the true default directly, since atomic.Bool's own zero value
+// is false and code that logs before InitLogging runs must still see
+// caller info rather than silently losing it.
+var callerEnabled atomic.Bool
+
+func init() {
+	callerEnabled.Store(true)
+}
+
 // ---------- Initialization ----------
 
 func InitLogging() {
@@ -85,6 +116,7 @@ func InitLogging() {
 
 	fileEnabled := getEnvBool("LOG_FILE", true)
 	consoleEnabled := getEnvBool("LOG_CONSOLE", true)
+	callerEnabled.Store(getEnvBool("LOG_CALLER", true))
 
 	consoleLevel := parseLevel(getEnv("LOG_CONSOLE_LEVEL", "info"))
 	if consoleLevel == zerolog.NoLevel {
@@ -100,7 +132,14 @@ func InitLogging() {
 		rotator = NewRotatingLogger(logDir)
 	}
 
+	otlpExporterFromEnv()
 	configureZerolog(rotator, logEnv, fileEnabled, consoleEnabled, consoleLevel, fileLevel)
+	installSignalLevelHandler()
+	parseLevelOverridesFromEnv()
+
+	if f := defaultKeyRedactorFromEnv(); f != nil {
+		AddFilter(f)
+	}
 
 	AppLogger = &Logger{
 		rotator:    rotator,
@@ -147,17 +186,27 @@ func configureZerolog(rotator *RotatingLogger, logEnv string, fileEnabled, conso
 	// Build destinations (0..N)
 	var dests []levelDest
 
-	// JSON file destination (optional)
+	// JSON (or LOG_FILE_OUTPUT-formatted) file destination (optional); its
+	// level lives in a shared atomic so SetFileLevel can swap it at runtime.
 	if fileEnabled && rotator != nil {
-		dests = append(dests, levelDest{w: rotator, min: fileLevel})
+		fa := newAtomicLevel(fileLevel)
+		setFileLevelAtomic(fa)
+		dests = append(dests, levelDest{w: fileDestWriter(rotator), min: fa})
+	} else {
+		setFileLevelAtomic(nil)
 	}
 
-	// Console destination
+	// Console destination; its level lives in a shared atomic so
+	// SetConsoleLevel/SIGUSR1/LevelHandler can swap it at runtime.
 	if consoleEnabled {
-		switch strings.ToLower(strings.TrimSpace(getEnv("LOG_CONSOLE_OUTPUT", "full"))) {
+		ca := newAtomicLevel(consoleLevel)
+		setConsoleLevelAtomic(ca)
+
+		consoleOutput := strings.ToLower(strings.TrimSpace(getEnv("LOG_CONSOLE_OUTPUT", "full")))
+		switch consoleOutput {
 		case "json":
 			// Raw JSON to stdout (for containers/collectors)
-			dests = append(dests, levelDest{w: os.Stdout, min: consoleLevel})
+			dests = append(dests, levelDest{w: os.Stdout, min: ca})
 		case "minimal":
 			// time, level, message only (no caller), hide env/service/ids
 			cw := zerolog.ConsoleWriter{
@@ -171,7 +220,7 @@ func configureZerolog(rotator *RotatingLogger, logEnv string, fileEnabled, conso
 				zerolog.MessageFieldName,
 			}
 			cw.FieldsExclude = []string{"service", "env", "trace_id", "span_id", "request_id"}
-			dests = append(dests, levelDest{w: &cw, min: consoleLevel})
+			dests = append(dests, levelDest{w: &cw, min: ca})
 		case "extended":
 			// time, level, caller, message, and all fields (no excludes)
 			cw := zerolog.ConsoleWriter{
@@ -185,10 +234,15 @@ func configureZerolog(rotator *RotatingLogger, logEnv string, fileEnabled, conso
 				zerolog.CallerFieldName,
 				zerolog.MessageFieldName,
 			}
-			dests = append(dests, levelDest{w: &cw, min: consoleLevel})
+			dests = append(dests, levelDest{w: &cw, min: ca})
 		case "full":
 			fallthrough
 		default:
+			if fn, ok := lookupFormat(consoleOutput); ok {
+				// Registered format (logfmt, gelf, or a custom RegisterFormat)
+				dests = append(dests, levelDest{w: &formatWriter{out: os.Stderr, fn: fn}, min: ca})
+				break
+			}
 			// time, level, caller, message; hide env/service/ids; keep other fields
 			cw := zerolog.ConsoleWriter{
 				Out:        os.Stderr,
@@ -202,33 +256,34 @@ func configureZerolog(rotator *RotatingLogger, logEnv string, fileEnabled, conso
 				zerolog.MessageFieldName,
 			}
 			cw.FieldsExclude = []string{"service", "env", "trace_id", "span_id", "request_id"}
-			dests = append(dests, levelDest{w: &cw, min: consoleLevel})
+			dests = append(dests, levelDest{w: &cw, min: ca})
 		}
+	} else {
+		setConsoleLevelAtomic(nil)
 	}
 
+	// OTLP destination(s) registered via WithOTLPExporter / LOG_OTLP_ENDPOINT
+	dests = append(dests, pendingOTLPDests()...)
+
 	// Safety fallback: if nothing enabled, write JSON to stdout at info
 	if len(dests) == 0 {
-		dests = append(dests, levelDest{w: os.Stdout, min: zerolog.InfoLevel})
+		dests = append(dests, levelDest{w: os.Stdout, min: newAtomicLevel(zerolog.InfoLevel)})
 	}
 
 	// Multi-destination writer
 	w := multiLevelWriter{dests: dests}
 
-	// Base logger uses lowest destination level so writers can filter independently
-	minLevel := dests[0].min
-	for _, d := range dests[1:] {
-		if d.min < minLevel {
-			minLevel = d.min
-		}
-	}
-
+	// The base logger itself stays at TraceLevel: per-destination min now
+	// lives in a swappable atomic.Int32 (see level.go), so SetConsoleLevel/
+	// SetFileLevel/SIGUSR1/LevelHandler can raise or lower verbosity at
+	// runtime. Gating happens in multiLevelWriter.WriteLevel instead.
 	logger := zerolog.New(w)
 	if n := getEnvInt("LOG_SAMPLING_N", 1); n > 1 {
 		logger = logger.Sample(&zerolog.BasicSampler{N: uint32(n)})
 	}
 
 	log.Logger = logger.
-		Level(minLevel).
+		Level(zerolog.TraceLevel).
 		With().
 		Timestamp().
 		Str("service", service).
@@ -236,11 +291,34 @@ func configureZerolog(rotator *RotatingLogger, logEnv string, fileEnabled, conso
 		Logger()
 }
 
+// fileDestWriter wraps rotator in a formatWriter when LOG_FILE_OUTPUT names
+// a registered format (logfmt, gelf, or a custom RegisterFormat); otherwise
+// it returns rotator unchanged so the file keeps receiving raw JSON.
+func fileDestWriter(rotator *RotatingLogger) io.Writer {
+	name := strings.ToLower(strings.TrimSpace(getEnv("LOG_FILE_OUTPUT", "json")))
+	if name == "json" || name == "" {
+		return rotator
+	}
+	if fn, ok := lookupFormat(name); ok {
+		return &formatWriter{out: rotator, fn: fn}
+	}
+	return rotator
+}
+
 // ----- multiLevelWriter: fan-out with per-destination min levels -----
 
 type levelDest struct {
 	w   io.Writer
-	min zerolog.Level
+	min *atomic.Int32 // holds a zerolog.Level; swappable at runtime, see level.go
+}
+
+// newAtomicLevel returns a levelDest.min seeded with l, for destinations
+// that don't need runtime control (OTLP, the stdout safety fallback) as well
+// as the console/file destinations tracked by SetConsoleLevel/SetFileLevel.
+func newAtomicLevel(l zerolog.Level) *atomic.Int32 {
+	a := &atomic.Int32{}
+	a.Store(int32(l))
+	return a
 }
 
 type multiLevelWriter struct {
@@ -258,7 +336,7 @@ func (m multiLevelWriter) Write(p []byte) (int, error) {
 
 func (m multiLevelWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
 	for _, d := range m.dests {
-		if d.w != nil && level >= d.min {
+		if d.w != nil && level >= zerolog.Level(d.min.Load()) {
 			_, _ = d.w.Write(p)
 		}
 	}
@@ -270,6 +348,7 @@ func (m multiLevelWriter) WriteLevel(level zerolog.Level, p []byte) (int, error)
 func (l *Logger) Shutdown() {
 	l.mu.Lock()
 	defer l.mu.Unlock()
+	shutdownOTLP()
 	if l.rotator != nil {
 		_ = l.rotator.Close()
 	}
@@ -315,6 +394,16 @@ func (l *Logger) Fatal(args ...interface{}) {
 	if c := userCaller(); c != "" {
 		ev = ev.Str("caller", c)
 	}
+	if l.component != "" {
+		ev = ev.Str("component", l.component)
+	}
+	// Registered filters rewrite a fatal event like any other; if one drops
+	// it, nothing is written (zerolog ignores a nil event) but the process
+	// still exits below.
+	message, fields, keep := runFilters(zerolog.FatalLevel, message, fields)
+	if !keep {
+		ev = nil
+	}
 	ev = ev.Fields(fields)
 	if errVal != nil {
 		ev = ev.Err(errVal)
@@ -346,22 +435,51 @@ func Fatal(args ...interface{}) { ensure().Fatal(args...) }
 // ---------- Internals ----------
 
 func (l *Logger) logEvent(level zerolog.Level, args ...interface{}) {
+	if ov := l.effectiveLevel(); ov != zerolog.NoLevel && level < ov {
+		return
+	}
+
+	buf := getFieldBuf()
+	message, errVal := processLogArgsInto(args, buf)
+
+	if hasFilters() {
+		fields := buf.toMap()
+		putFieldBuf(buf)
+
+		message, fields, ok := runFilters(level, message, fields)
+		if !ok {
+			return
+		}
+		ev := log.WithLevel(level)
+		if c := userCaller(); c != "" {
+			ev = ev.Str("caller", c)
+		}
+		if l.component != "" {
+			ev = ev.Str("component", l.component)
+		}
+		for k, v := range fields {
+			ev = ev.Interface(k, v)
+		}
+		if errVal != nil {
+			ev = ev.Err(errVal)
+		}
+		emitEvent(ev, message)
+		return
+	}
+	defer putFieldBuf(buf)
+
 	ev := log.WithLevel(level)
 	if c := userCaller(); c != "" {
 		ev = ev.Str("caller", c)
 	}
-	message, fields, errVal := processLogArgs(args)
-	for k, v := range fields {
-		ev = ev.Interface(k, v)
+	if l.component != "" {
+		ev = ev.Str("component", l.component)
 	}
+	writeFieldsTyped(ev, buf.entries)
 	if errVal != nil {
 		ev = ev.Err(errVal)
 	}
-	if message != "" {
-		ev.Msg(message)
-	} else {
-		ev.Send()
-	}
+	emitEvent(ev, message)
 }
 
 func (l *Logger) logEventCtx(ctx context.Context, level zerolog.Level, args ...interface{}) {
@@ -377,53 +495,61 @@ func (l *Logger) logEventCtx(ctx context.Context, level zerolog.Level, args ...i
 	}
 	child := e.Logger()
 
+	if ov := l.effectiveLevel(); ov != zerolog.NoLevel && level < ov {
+		return
+	}
+
+	buf := getFieldBuf()
+	message, errVal := processLogArgsInto(args, buf)
+
+	if hasFilters() {
+		fields := buf.toMap()
+		putFieldBuf(buf)
+
+		message, fields, ok := runFilters(level, message, fields)
+		if !ok {
+			return
+		}
+		ev := child.WithLevel(level)
+		if c := userCaller(); c != "" {
+			ev = ev.Str("caller", c)
+		}
+		if l.component != "" {
+			ev = ev.Str("component", l.component)
+		}
+		for k, v := range fields {
+			ev = ev.Interface(k, v)
+		}
+		if errVal != nil {
+			ev = ev.Err(errVal)
+		}
+		emitEvent(ev, message)
+		return
+	}
+	defer putFieldBuf(buf)
+
 	ev := child.WithLevel(level)
 	if c := userCaller(); c != "" {
 		ev = ev.Str("caller", c)
 	}
-	message, fields, errVal := processLogArgs(args)
-	for k, v := range fields {
-		ev = ev.Interface(k, v)
+	if l.component != "" {
+		ev = ev.Str("component", l.component)
 	}
+	writeFieldsTyped(ev, buf.entries)
 	if errVal != nil {
 		ev = ev.Err(errVal)
 	}
-	if message != "" {
-		ev.Msg(message)
-	} else {
-		ev.Send()
-	}
+	emitEvent(ev, message)
 }
 
+// processLogArgs is the map-returning form of processLogArgsInto, used by
+// Fatal, which adds "stack" to the map and runs it through the registered
+// filters (they operate on map[string]interface{}).
 func processLogArgs(args []interface{}) (string, map[string]interface{}, error) {
-	var message string
-	fields := make(map[string]interface{})
-	var errVal error
-	for _, arg := range args {
-		switch v := arg.(type) {
-		case string:
-			message = v
-		case error:
-			errVal = v
-		case map[string]interface{}:
-			mergeMap(fields, v)
-		case fmt.Stringer:
-			if message == "" {
-				message = v.String()
-			} else {
-				fields["value"] = v.String()
-			}
-		default:
-			mergeStruct(fields, v)
-		}
-	}
-	return message, fields, errVal
-}
-
-func mergeMap(target map[string]interface{}, source map[string]interface{}) {
-	for k, v := range source {
-		target[k] = v
-	}
+	buf := getFieldBuf()
+	defer putFieldBuf(buf)
+	message, errVal := processLogArgsInto(args, buf)
+	return message, buf.toMap(), errVal
 }
 
 func mergeStruct(target map[string]interface{}, obj interface{}) {
@@ -453,32 +579,38 @@ func mergeStruct(target map[string]interface{}, obj interface{}) {
 
 // ---------- Caller helpers ----------
 
-// userCaller returns "file.go:line" for the first stack frame outside this package.
+// userCaller returns "file.go:line" for the first stack frame outside this
+// package, or "" if LOG_CALLER=false. This is the most expensive part of a
+// log call (a stack walk plus a per-frame name check), so it's skippable
+// for callers chasing minimal per-call allocations.
 func userCaller() string {
-	// Walk a reasonable number of frames upward
-	for i := 2; i < 30; i++ {
-		pc, file, line, ok := runtime.Caller(i)
-		if !ok {
-			break
+	if !callerEnabled.Load() {
+		return ""
+	}
+
+	// runtime.Callers fills pcs in one shot; runtime.CallersFrames then
+	// resolves file/line lazily, which is cheaper than the old approach of
+	// calling runtime.Caller (a full stack walk each time) per frame.
+	var pcs [16]uintptr
+	n := runtime.Callers(3, pcs[:]) // skip Callers, userCaller, and its direct caller
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !isThisPackageFrame(f.Function, f.File) {
+			return fmt.Sprintf("%s:%d", filepath.Base(f.File), f.Line)
 		}
-		if !isThisPackageFrame(pc, file) {
-			return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+		if !more {
+			return ""
 		}
 	}
-	return ""
 }
 
-func isThisPackageFrame(pc uintptr, file string) bool {
-	// Check by function name (most robust across module paths)
-	if f := runtime.FuncForPC(pc); f != nil {
-		name := strings.ToLower(f.Name())
-		// e.g., "github.com/renegadevi/prezerolog.(*Logger).Info"
-		if strings.Contains(name, "/prezerolog.") || strings.Contains(name, ".prezerolog.") {
-			return true
-		}
+func isThisPackageFrame(funcName, file string) bool {
+	// e.g., "github.com/renegadevi/prezerolog.(*Logger).Info"
+	if strings.Contains(funcName, "/prezerolog.") || strings.Contains(funcName, ".prezerolog.") {
+		return true
 	}
-	p := strings.ToLower(filepath.ToSlash(file))
-	return strings.Contains(p, "/prezerolog/")
+	return strings.Contains(filepath.ToSlash(file), "/prezerolog/")
 }
 
 // ---------- Package-level shortcuts ----------
@@ -0,0 +1,324 @@
+// Slog bridge: lets applications adopt log/slog as the programming surface
+// while still emitting through prezerolog's configured destinations (rotated
+// JSON file, console, sampling) with the same normalized field names.
+
+package prezerolog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// LevelFatal mirrors zerolog's fatal semantics for slog callers. slog has no
+// built-in fatal level, so code that wants FatalCode-style exits should log
+// at this level, e.g. slog.Log(ctx, prezerolog.LevelFatal, "msg").
+// An optional int attr named "code" sets the process exit code (default 1).
+const LevelFatal slog.Level = 12
+
+// Option configures a slog.Handler created by NewSlogHandler.
+type Option func(*slogConfig)
+
+type slogConfig struct {
+	logger *zerolog.Logger
+	level  slog.Leveler
+}
+
+// WithSlogLogger overrides the zerolog.Logger the handler writes through.
+// Defaults to the globally configured logger set up by InitLogging.
+func WithSlogLogger(zl zerolog.Logger) Option {
+	return func(c *slogConfig) { c.logger = &zl }
+}
+
+// WithSlogLevel overrides the minimum level the handler reports as enabled.
+// Destination-level filtering (console/file) still applies downstream.
+func WithSlogLevel(l slog.Leveler) Option {
+	return func(c *slogConfig) { c.level = l }
+}
+
+// groupOrAttrs is one entry recorded by WithGroup or WithAttrs, kept in call
+// order so Handle can replay them and qualify each attr with only the groups
+// that were open when it was added - a group opened after an attr must not
+// retroactively nest it.
+type groupOrAttrs struct {
+	group string      // set if this entry came from WithGroup
+	attrs []slog.Attr // set if this entry came from WithAttrs
+}
+
+// SlogHandler implements slog.Handler by writing events directly through a
+// prezerolog-configured zerolog.Logger, so there is no intermediate format
+// translation between slog and the JSON/console output already in use.
+type SlogHandler struct {
+	logger *zerolog.Logger
+	level  slog.Leveler
+	groups []groupOrAttrs
+
+	// hasTimestampHook is true when logger already auto-stamps "time" on
+	// every event (the InitLogging default, via .With().Timestamp()), so
+	// Handle must not also write the field from r.Time and double it up.
+	// zerolog doesn't expose a way to inspect a *Logger's hooks from
+	// outside the package, so this is tracked by construction instead: the
+	// package default always carries the hook, and a caller-supplied
+	// logger (WithSlogLogger) is assumed not to.
+	hasTimestampHook bool
+}
+
+// NewSlogHandler returns a slog.Handler that emits through the same
+// multiLevelWriter configured by InitLogging, so slog.Logger and the
+// package's Info/Error/... helpers produce identical output.
+func NewSlogHandler(opts ...Option) slog.Handler {
+	cfg := slogConfig{level: slog.LevelInfo}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	hasTimestampHook := cfg.logger == nil
+	if cfg.logger == nil {
+		ensure()
+		cfg.logger = &log.Logger
+	}
+	return &SlogHandler{logger: cfg.logger, level: cfg.level, hasTimestampHook: hasTimestampHook}
+}
+
+// SlogLogger returns a *slog.Logger wired to NewSlogHandler, letting callers
+// and third-party libraries that log through slog produce output identical
+// to InfoCtx/ErrorCtx and friends.
+func SlogLogger(opts ...Option) *slog.Logger {
+	return slog.New(NewSlogHandler(opts...))
+}
+
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+func (h *SlogHandler) Handle(_ context.Context, r slog.Record) error {
+	ev := h.logger.WithLevel(slogToZerolog(r.Level))
+
+	// Per the slog.Handler contract, a zero r.Time means the caller didn't
+	// want one and Handle must not invent it. Skip the explicit field when
+	// the logger already carries a Timestamp() hook (the InitLogging
+	// default) - the hook stamps every event regardless, and adding our own
+	// would just duplicate the key.
+	if !r.Time.IsZero() && !h.hasTimestampHook {
+		ev = ev.Time(zerolog.TimestampFieldName, r.Time)
+	}
+
+	if r.PC != 0 {
+		frames := runtime.CallersFrames([]uintptr{r.PC})
+		if f, _ := frames.Next(); f.File != "" {
+			ev = ev.Str("caller", fmt.Sprintf("%s:%d", filepath.Base(f.File), f.Line))
+		}
+	}
+
+	var recordAttrs []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		recordAttrs = append(recordAttrs, a)
+		return true
+	})
+	chain := append(append([]groupOrAttrs{}, h.groups...), groupOrAttrs{attrs: recordAttrs})
+	writeGroupChain(ev, chain)
+
+	if r.Message != "" {
+		ev.Msg(r.Message)
+	} else {
+		ev.Send()
+	}
+
+	if r.Level >= LevelFatal {
+		code := 1
+		r.Attrs(func(a slog.Attr) bool {
+			if a.Key == "code" && a.Value.Kind() == slog.KindInt64 {
+				code = int(a.Value.Int64())
+			}
+			return true
+		})
+		os.Exit(code)
+	}
+	return nil
+}
+
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	n := *h
+	n.groups = append(append([]groupOrAttrs{}, h.groups...), groupOrAttrs{attrs: attrs})
+	return &n
+}
+
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	n := *h
+	n.groups = append(append([]groupOrAttrs{}, h.groups...), groupOrAttrs{group: name})
+	return &n
+}
+
+// ContextHandler wraps another slog.Handler and automatically attaches the
+// request/trace/span correlation IDs carried on the context, mirroring
+// InfoCtx/ErrorCtx for slog-based call sites.
+type ContextHandler struct {
+	slog.Handler
+}
+
+// NewContextHandler wraps h so every Handle call picks up CtxRequestID,
+// CtxTraceID, and CtxSpanID from the context automatically.
+func NewContextHandler(h slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: h}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if v, _ := ctx.Value(CtxRequestID).(string); v != "" {
+		r.AddAttrs(slog.String("request_id", v))
+	}
+	if v, _ := ctx.Value(CtxTraceID).(string); v != "" {
+		r.AddAttrs(slog.String("trace_id", v))
+	}
+	if v, _ := ctx.Value(CtxSpanID).(string); v != "" {
+		r.AddAttrs(slog.String("span_id", v))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+func slogToZerolog(l slog.Level) zerolog.Level {
+	switch {
+	case l >= LevelFatal:
+		return zerolog.FatalLevel
+	case l >= slog.LevelError:
+		return zerolog.ErrorLevel
+	case l >= slog.LevelWarn:
+		return zerolog.WarnLevel
+	case l >= slog.LevelInfo:
+		return zerolog.InfoLevel
+	case l >= slog.LevelDebug:
+		return zerolog.DebugLevel
+	default:
+		return zerolog.TraceLevel
+	}
+}
+
+// writeGroupChain replays entries (the handler's recorded WithGroup/WithAttrs
+// calls, plus the record's own attrs appended as a final entry) onto ev.
+// Because WithGroup only ever nests deeper, the chain is a simple path: a
+// group entry wraps every entry after it in a zerolog dict, recursively, so
+// "a".WithGroup("g").With("b") ends up with "a" at the top level and "b"
+// nested under "g" - exactly which attrs a group qualifies depends on when
+// it was opened, not on replaying one flat trailing slice.
+func writeGroupChain(ev *zerolog.Event, entries []groupOrAttrs) {
+	for i, g := range entries {
+		if g.group == "" {
+			flattenAttrs(ev, g.attrs)
+			continue
+		}
+		// Per the slog.Handler contract: a group that ends up with no
+		// attrs, directly or nested, must not appear in the output.
+		if !chainHasContent(entries[i+1:]) {
+			return
+		}
+		dict := ev.CreateDict()
+		writeGroupChain(dict, entries[i+1:])
+		ev.Dict(g.group, dict)
+		return
+	}
+}
+
+func chainHasContent(entries []groupOrAttrs) bool {
+	for i, g := range entries {
+		if g.group == "" {
+			for _, a := range g.attrs {
+				if attrHasContent(a) {
+					return true
+				}
+			}
+			continue
+		}
+		return chainHasContent(entries[i+1:])
+	}
+	return false
+}
+
+func attrHasContent(a slog.Attr) bool {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		for _, ga := range a.Value.Group() {
+			if attrHasContent(ga) {
+				return true
+			}
+		}
+		return false
+	}
+	return a.Key != ""
+}
+
+func flattenAttrs(ev *zerolog.Event, attrs []slog.Attr) {
+	for _, a := range attrs {
+		flattenAttr(ev, a)
+	}
+}
+
+func flattenAttr(ev *zerolog.Event, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		// Per the slog.Handler contract: a group with no attrs is ignored
+		// entirely, and a group with an empty key inlines its attrs into
+		// the parent instead of nesting them.
+		ga := a.Value.Group()
+		if len(ga) == 0 {
+			return
+		}
+		if a.Key == "" {
+			flattenAttrs(ev, ga)
+			return
+		}
+		dict := ev.CreateDict()
+		flattenAttrs(dict, ga)
+		ev.Dict(a.Key, dict)
+		return
+	}
+
+	// Per the slog.Handler contract: an Attr with an empty key (and not a
+	// group) is ignored.
+	if a.Key == "" {
+		return
+	}
+
+	if a.Key == "err" || a.Key == "error" {
+		if err, ok := a.Value.Any().(error); ok {
+			ev.AnErr(a.Key, err)
+			return
+		}
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		ev.Str(a.Key, a.Value.String())
+	case slog.KindInt64:
+		ev.Int64(a.Key, a.Value.Int64())
+	case slog.KindUint64:
+		ev.Uint64(a.Key, a.Value.Uint64())
+	case slog.KindFloat64:
+		ev.Float64(a.Key, a.Value.Float64())
+	case slog.KindBool:
+		ev.Bool(a.Key, a.Value.Bool())
+	case slog.KindDuration:
+		ev.Dur(a.Key, a.Value.Duration())
+	case slog.KindTime:
+		ev.Time(a.Key, a.Value.Time())
+	default:
+		ev.Interface(a.Key, a.Value.Any())
+	}
+}